@@ -0,0 +1,19 @@
+package main
+
+import "net/http"
+
+// apiError is the uniform error envelope returned by the JSON API
+// handlers: {"error": {"code", "message", "details"}}. auth.Middleware
+// and streamTodos answer a missing or invalid token with a plain-text
+// 401 instead.
+type apiError struct {
+	Code    string      `json:"code"`
+	Message string      `json:"message"`
+	Details interface{} `json:"details,omitempty"`
+}
+
+func writeError(w http.ResponseWriter, status int, code, message string, details interface{}) {
+	rnd.JSON(w, status, map[string]apiError{
+		"error": {Code: code, Message: message, Details: details},
+	})
+}
@@ -0,0 +1,49 @@
+package main
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/jalal-akbar/todo/realtime"
+	"github.com/jalal-akbar/todo/storage"
+)
+
+func newTestApp() *application {
+	return &application{
+		store:     storage.NewMemoryStore(),
+		hub:       realtime.NewHub(),
+		jwtSecret: "test-secret",
+	}
+}
+
+func TestCreateTodoRejectsMissingTitle(t *testing.T) {
+	app := newTestApp()
+	r := httptest.NewRequest("POST", "/todo", strings.NewReader(`{"title":""}`))
+	w := httptest.NewRecorder()
+
+	app.createTodo(w, r)
+
+	if w.Code != http.StatusUnprocessableEntity {
+		t.Fatalf("status = %d, want %d", w.Code, http.StatusUnprocessableEntity)
+	}
+	if !strings.Contains(w.Body.String(), `"code":"validation_failed"`) {
+		t.Errorf("body = %s, want validation_failed envelope", w.Body.String())
+	}
+}
+
+func TestCreateTodoRejectsMalformedJSON(t *testing.T) {
+	app := newTestApp()
+	r := httptest.NewRequest("POST", "/todo", strings.NewReader(`{not json`))
+	w := httptest.NewRecorder()
+
+	app.createTodo(w, r)
+
+	if w.Code != http.StatusBadRequest {
+		t.Fatalf("status = %d, want %d", w.Code, http.StatusBadRequest)
+	}
+	if !strings.Contains(w.Body.String(), `"code":"invalid_request"`) {
+		t.Errorf("body = %s, want invalid_request envelope", w.Body.String())
+	}
+}
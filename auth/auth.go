@@ -0,0 +1,66 @@
+// Package auth issues and validates the JWTs used to authenticate API
+// requests, and hashes/verifies user passwords.
+package auth
+
+import (
+	"errors"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// ErrInvalidToken is returned by ParseToken when the token is missing,
+// malformed, expired, or signed with the wrong secret.
+var ErrInvalidToken = errors.New("auth: invalid token")
+
+// HashPassword bcrypt-hashes password for storage.
+func HashPassword(password string) (string, error) {
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	if err != nil {
+		return "", err
+	}
+	return string(hash), nil
+}
+
+// CheckPassword reports whether password matches hash.
+func CheckPassword(hash, password string) bool {
+	return bcrypt.CompareHashAndPassword([]byte(hash), []byte(password)) == nil
+}
+
+type claims struct {
+	jwt.RegisteredClaims
+}
+
+// NewToken issues an HS256 JWT for userID, signed with secret, valid
+// for ttl.
+func NewToken(userID, secret string, ttl time.Duration) (string, error) {
+	now := time.Now()
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims{
+		RegisteredClaims: jwt.RegisteredClaims{
+			Subject:   userID,
+			IssuedAt:  jwt.NewNumericDate(now),
+			ExpiresAt: jwt.NewNumericDate(now.Add(ttl)),
+		},
+	})
+	return token.SignedString([]byte(secret))
+}
+
+// ParseToken validates tokenString against secret and returns the
+// userID it was issued for.
+func ParseToken(tokenString, secret string) (string, error) {
+	token, err := jwt.ParseWithClaims(tokenString, &claims{}, func(t *jwt.Token) (interface{}, error) {
+		if _, ok := t.Method.(*jwt.SigningMethodHMAC); !ok {
+			return nil, ErrInvalidToken
+		}
+		return []byte(secret), nil
+	})
+	if err != nil || !token.Valid {
+		return "", ErrInvalidToken
+	}
+	c, ok := token.Claims.(*claims)
+	if !ok || c.Subject == "" {
+		return "", ErrInvalidToken
+	}
+	return c.Subject, nil
+}
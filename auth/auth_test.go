@@ -0,0 +1,54 @@
+package auth
+
+import (
+	"testing"
+	"time"
+)
+
+func TestHashAndCheckPassword(t *testing.T) {
+	hash, err := HashPassword("correct horse battery staple")
+	if err != nil {
+		t.Fatalf("HashPassword() error = %v", err)
+	}
+	if !CheckPassword(hash, "correct horse battery staple") {
+		t.Error("CheckPassword() = false, want true for the correct password")
+	}
+	if CheckPassword(hash, "wrong password") {
+		t.Error("CheckPassword() = true, want false for the wrong password")
+	}
+}
+
+func TestNewTokenAndParseToken(t *testing.T) {
+	token, err := NewToken("user-123", "secret", time.Hour)
+	if err != nil {
+		t.Fatalf("NewToken() error = %v", err)
+	}
+
+	userID, err := ParseToken(token, "secret")
+	if err != nil {
+		t.Fatalf("ParseToken() error = %v", err)
+	}
+	if userID != "user-123" {
+		t.Errorf("ParseToken() userID = %q, want %q", userID, "user-123")
+	}
+}
+
+func TestParseTokenRejectsWrongSecret(t *testing.T) {
+	token, err := NewToken("user-123", "secret", time.Hour)
+	if err != nil {
+		t.Fatalf("NewToken() error = %v", err)
+	}
+	if _, err := ParseToken(token, "other-secret"); err != ErrInvalidToken {
+		t.Errorf("ParseToken() error = %v, want ErrInvalidToken", err)
+	}
+}
+
+func TestParseTokenRejectsExpired(t *testing.T) {
+	token, err := NewToken("user-123", "secret", -time.Minute)
+	if err != nil {
+		t.Fatalf("NewToken() error = %v", err)
+	}
+	if _, err := ParseToken(token, "secret"); err != ErrInvalidToken {
+		t.Errorf("ParseToken() error = %v, want ErrInvalidToken", err)
+	}
+}
@@ -0,0 +1,42 @@
+package auth
+
+import (
+	"context"
+	"net/http"
+	"strings"
+)
+
+type contextKey string
+
+const userIDContextKey contextKey = "userID"
+
+// Middleware validates the "Authorization: Bearer <token>" header on
+// every request and injects the authenticated userID into the request
+// context. Requests without a valid token are rejected with 401.
+func Middleware(secret string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			header := r.Header.Get("Authorization")
+			tokenString := strings.TrimPrefix(header, "Bearer ")
+			if tokenString == "" || tokenString == header {
+				http.Error(w, "missing bearer token", http.StatusUnauthorized)
+				return
+			}
+
+			userID, err := ParseToken(tokenString, secret)
+			if err != nil {
+				http.Error(w, "invalid or expired token", http.StatusUnauthorized)
+				return
+			}
+
+			ctx := context.WithValue(r.Context(), userIDContextKey, userID)
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
+
+// UserIDFromContext returns the userID injected by Middleware, if any.
+func UserIDFromContext(ctx context.Context) (string, bool) {
+	userID, ok := ctx.Value(userIDContextKey).(string)
+	return userID, ok
+}
@@ -0,0 +1,83 @@
+package main
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/go-chi/chi"
+	"github.com/thedevsaddam/renderer"
+
+	"github.com/jalal-akbar/todo/auth"
+	"github.com/jalal-akbar/todo/storage"
+)
+
+type credentials struct {
+	Email    string `json:"email" validate:"required,email"`
+	Password string `json:"password" validate:"required,min=8"`
+}
+
+func (a *application) authHandlers() http.Handler {
+	rAuth := chi.NewRouter()
+	rAuth.Post("/register", a.register)
+	rAuth.Post("/login", a.login)
+	return rAuth
+}
+
+func (a *application) register(w http.ResponseWriter, r *http.Request) {
+	var c credentials
+	if !decodeJSON(w, r, &c) {
+		return
+	}
+	c.Email = strings.TrimSpace(strings.ToLower(c.Email))
+	if !validateStruct(w, &c) {
+		return
+	}
+
+	hash, err := auth.HashPassword(c.Password)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "internal_error", "failed to register user", nil)
+		return
+	}
+
+	u := storage.User{Email: c.Email, PasswordHash: hash}
+	if err := a.users.Create(r.Context(), &u); err != nil {
+		if err == storage.ErrUserExists {
+			writeError(w, http.StatusConflict, "conflict", "email already registered", nil)
+			return
+		}
+		writeError(w, http.StatusInternalServerError, "internal_error", "failed to register user", nil)
+		return
+	}
+
+	rnd.JSON(w, http.StatusCreated, renderer.M{
+		"message": "user registered successfully",
+		"user_id": u.ID,
+	})
+}
+
+func (a *application) login(w http.ResponseWriter, r *http.Request) {
+	var c credentials
+	if !decodeJSON(w, r, &c) {
+		return
+	}
+	c.Email = strings.TrimSpace(strings.ToLower(c.Email))
+	if !validateStruct(w, &c) {
+		return
+	}
+
+	u, err := a.users.GetByEmail(r.Context(), c.Email)
+	if err != nil || !auth.CheckPassword(u.PasswordHash, c.Password) {
+		writeError(w, http.StatusUnauthorized, "unauthorized", "invalid email or password", nil)
+		return
+	}
+
+	token, err := auth.NewToken(u.ID, a.jwtSecret, a.jwtTTL)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "internal_error", "failed to issue token", nil)
+		return
+	}
+
+	rnd.JSON(w, http.StatusOK, renderer.M{
+		"token": token,
+	})
+}
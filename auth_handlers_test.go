@@ -0,0 +1,117 @@
+package main
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/jalal-akbar/todo/storage"
+)
+
+func newAuthTestApp() *application {
+	app := newTestApp()
+	app.users = storage.NewMemoryUserStore()
+	return app
+}
+
+func TestRegisterCreatesUser(t *testing.T) {
+	app := newAuthTestApp()
+	router := app.authHandlers()
+
+	r := httptest.NewRequest("POST", "/register", strings.NewReader(`{"email":"alice@example.com","password":"hunter22"}`))
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, r)
+
+	if w.Code != http.StatusCreated {
+		t.Fatalf("status = %d, body = %s", w.Code, w.Body.String())
+	}
+
+	if _, err := app.users.GetByEmail(r.Context(), "alice@example.com"); err != nil {
+		t.Errorf("GetByEmail() error = %v, want registered user", err)
+	}
+}
+
+func TestRegisterRejectsDuplicateEmail(t *testing.T) {
+	app := newAuthTestApp()
+	router := app.authHandlers()
+
+	body := `{"email":"alice@example.com","password":"hunter22"}`
+	router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("POST", "/register", strings.NewReader(body)))
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("POST", "/register", strings.NewReader(body)))
+
+	if w.Code != http.StatusConflict {
+		t.Fatalf("status = %d, body = %s, want %d", w.Code, w.Body.String(), http.StatusConflict)
+	}
+}
+
+func TestRegisterRejectsInvalidCredentials(t *testing.T) {
+	app := newAuthTestApp()
+	router := app.authHandlers()
+
+	cases := []struct {
+		name string
+		body string
+	}{
+		{"missing email", `{"email":"","password":"hunter22"}`},
+		{"malformed email", `{"email":"not-an-email","password":"hunter22"}`},
+		{"short password", `{"email":"alice@example.com","password":"short"}`},
+	}
+
+	for _, c := range cases {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest("POST", "/register", strings.NewReader(c.body)))
+		if w.Code != http.StatusUnprocessableEntity {
+			t.Errorf("%s: status = %d, want %d", c.name, w.Code, http.StatusUnprocessableEntity)
+		}
+	}
+}
+
+func TestLoginIssuesToken(t *testing.T) {
+	app := newAuthTestApp()
+	router := app.authHandlers()
+
+	body := `{"email":"alice@example.com","password":"hunter22"}`
+	router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("POST", "/register", strings.NewReader(body)))
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("POST", "/login", strings.NewReader(body)))
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("status = %d, body = %s", w.Code, w.Body.String())
+	}
+	if !strings.Contains(w.Body.String(), `"token"`) {
+		t.Errorf("body = %s, want a token", w.Body.String())
+	}
+}
+
+func TestLoginRejectsWrongPassword(t *testing.T) {
+	app := newAuthTestApp()
+	router := app.authHandlers()
+
+	router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("POST", "/register", strings.NewReader(
+		`{"email":"alice@example.com","password":"hunter22"}`)))
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("POST", "/login", strings.NewReader(
+		`{"email":"alice@example.com","password":"wrongpass"}`)))
+
+	if w.Code != http.StatusUnauthorized {
+		t.Fatalf("status = %d, body = %s, want %d", w.Code, w.Body.String(), http.StatusUnauthorized)
+	}
+}
+
+func TestLoginRejectsUnknownEmail(t *testing.T) {
+	app := newAuthTestApp()
+	router := app.authHandlers()
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("POST", "/login", strings.NewReader(
+		`{"email":"nobody@example.com","password":"hunter22"}`)))
+
+	if w.Code != http.StatusUnauthorized {
+		t.Fatalf("status = %d, body = %s, want %d", w.Code, w.Body.String(), http.StatusUnauthorized)
+	}
+}
@@ -0,0 +1,68 @@
+package main
+
+import (
+	"os"
+	"time"
+)
+
+// Config holds the process's runtime settings, populated from
+// environment variables so the app follows 12-factor style
+// configuration (e.g. for Docker/Kubernetes deployments).
+type Config struct {
+	MongoURI        string
+	MongoDB         string
+	HTTPAddr        string
+	ReadTimeout     time.Duration
+	WriteTimeout    time.Duration
+	IdleTimeout     time.Duration
+	ShutdownTimeout time.Duration
+	JWTSecret       string
+	JWTTTL          time.Duration
+	// RealtimeSource selects how todo mutation events reach the Hub:
+	// realtimeSourceInProcess (default) has handlers broadcast directly
+	// after their own writes; realtimeSourceChangeStream watches MongoDB
+	// instead, so every API instance stays in sync with writes made by
+	// any of the others.
+	RealtimeSource string
+}
+
+const (
+	realtimeSourceInProcess    = "inprocess"
+	realtimeSourceChangeStream = "changestream"
+)
+
+// loadConfig reads Config from the environment, falling back to the
+// same defaults the app previously hard-coded.
+func loadConfig() Config {
+	return Config{
+		MongoURI:        getEnv("MONGO_URI", "mongodb://localhost:27017"),
+		MongoDB:         getEnv("MONGO_DB", "demo_todo"),
+		HTTPAddr:        getEnv("HTTP_ADDR", ":9000"),
+		ReadTimeout:     getEnvDuration("READ_TIMEOUT", 60*time.Second),
+		WriteTimeout:    getEnvDuration("WRITE_TIMEOUT", 60*time.Second),
+		IdleTimeout:     getEnvDuration("IDLE_TIMEOUT", 60*time.Second),
+		ShutdownTimeout: getEnvDuration("SHUTDOWN_TIMEOUT", 5*time.Second),
+		JWTSecret:       getEnv("JWT_SECRET", "dev-secret-change-me"),
+		JWTTTL:          getEnvDuration("JWT_TTL", 24*time.Hour),
+		RealtimeSource:  getEnv("REALTIME_SOURCE", realtimeSourceInProcess),
+	}
+}
+
+func getEnv(key, fallback string) string {
+	if v := os.Getenv(key); v != "" {
+		return v
+	}
+	return fallback
+}
+
+func getEnvDuration(key string, fallback time.Duration) time.Duration {
+	v := os.Getenv(key)
+	if v == "" {
+		return fallback
+	}
+	d, err := time.ParseDuration(v)
+	if err != nil {
+		return fallback
+	}
+	return d
+}
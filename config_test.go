@@ -0,0 +1,34 @@
+package main
+
+import (
+	"testing"
+	"time"
+)
+
+func TestLoadConfigDefaults(t *testing.T) {
+	cfg := loadConfig()
+
+	if cfg.MongoURI != "mongodb://localhost:27017" {
+		t.Errorf("MongoURI = %q, want default", cfg.MongoURI)
+	}
+	if cfg.HTTPAddr != ":9000" {
+		t.Errorf("HTTPAddr = %q, want %q", cfg.HTTPAddr, ":9000")
+	}
+	if cfg.ShutdownTimeout != 5*time.Second {
+		t.Errorf("ShutdownTimeout = %s, want 5s", cfg.ShutdownTimeout)
+	}
+}
+
+func TestLoadConfigFromEnv(t *testing.T) {
+	t.Setenv("HTTP_ADDR", ":8080")
+	t.Setenv("SHUTDOWN_TIMEOUT", "2s")
+
+	cfg := loadConfig()
+
+	if cfg.HTTPAddr != ":8080" {
+		t.Errorf("HTTPAddr = %q, want %q", cfg.HTTPAddr, ":8080")
+	}
+	if cfg.ShutdownTimeout != 2*time.Second {
+		t.Errorf("ShutdownTimeout = %s, want 2s", cfg.ShutdownTimeout)
+	}
+}
@@ -0,0 +1,15 @@
+package main
+
+import "net/http"
+
+const maxRequestBodyBytes = 64 * 1024 // 64 KiB
+
+// limitRequestBody caps every request body at maxRequestBodyBytes so a
+// client can't exhaust memory with an oversized payload; json.Decode
+// fails once the limit is exceeded.
+func limitRequestBody(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		r.Body = http.MaxBytesReader(w, r.Body, maxRequestBodyBytes)
+		next.ServeHTTP(w, r)
+	})
+}
@@ -2,101 +2,188 @@ package main
 
 import (
 	"context"
-	"encoding/json"
 	"log"
 	"net/http"
 	"os"
 	"os/signal"
+	"strconv"
 	"strings"
 	"time"
 
 	"github.com/go-chi/chi"
 	"github.com/go-chi/chi/middleware"
 	"github.com/thedevsaddam/renderer"
-	mgo "gopkg.in/mgo.v2"
-	"gopkg.in/mgo.v2/bson"
+
+	"github.com/jalal-akbar/todo/auth"
+	"github.com/jalal-akbar/todo/realtime"
+	"github.com/jalal-akbar/todo/storage"
 )
 
 var rnd *renderer.Render
-var db *mgo.Database
 
 const (
-	hostName       = "localhost:27017"
-	dbName         = "demo_todo"
-	collectionName = "todo"
-	port           = ":9000"
-)
+	collectionName     = "todo"
+	userCollectionName = "users"
 
-type (
-	todoModel struct {
-		ID        bson.ObjectId `bson:"_id,omitempty"`
-		Title     string        `bson:"title"`
-		Completed bool          `bson:"completed"`
-		CreatedAt time.Time     `bson:"createdAt"`
-	}
-	todo struct {
-		ID        bson.ObjectId `json:"id"`
-		Title     string        `json:"title"`
-		Completed bool          `json:"completed"`
-		CreatedAt time.Time     `json:"createdAt"`
-	}
+	defaultLimit = 20
+	maxLimit     = 100
 )
 
+type todo struct {
+	ID        string    `json:"id"`
+	Title     string    `json:"title" validate:"required,max=200"`
+	Completed bool      `json:"completed"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+// application wires the HTTP handlers to their dependencies so tests
+// can substitute an in-memory TodoStore instead of MongoDB.
+type application struct {
+	store     storage.TodoStore
+	users     storage.UserStore
+	hub       *realtime.Hub
+	jwtSecret string
+	jwtTTL    time.Duration
+	// realtimeSource mirrors Config.RealtimeSource so the CRUD handlers
+	// know whether they should publish to hub themselves or leave that
+	// to watchChangeStream, which is already doing it for every
+	// instance watching the same collection.
+	realtimeSource string
+}
+
 func init() {
 	rnd = renderer.New()
-	sess, err := mgo.Dial(hostName)
-	checkErr(err)
-	sess.SetMode(mgo.Monotonic, true)
-	db = sess.DB(dbName)
 }
 
 func main() {
-	//stop channel
-	// stopChannel := make(chan os.Signal)
+	cfg := loadConfig()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	client, err := storage.Connect(ctx, cfg.MongoURI)
+	checkErr(err)
+	users, err := storage.NewMongoUserStore(ctx, client, cfg.MongoDB, userCollectionName)
+	checkErr(err)
+	cancel()
+
+	store := storage.NewMongoStore(client, cfg.MongoDB, collectionName)
+
+	hub := realtime.NewHub()
+	hubStop := make(chan struct{})
+	go hub.Run(hubStop)
+	if cfg.RealtimeSource == realtimeSourceChangeStream {
+		go watchChangeStream(store, hub)
+	}
+
+	app := &application{
+		store:          store,
+		users:          users,
+		hub:            hub,
+		jwtSecret:      cfg.JWTSecret,
+		jwtTTL:         cfg.JWTTTL,
+		realtimeSource: cfg.RealtimeSource,
+	}
+
 	stopChannel := make(chan os.Signal, 1)
 	signal.Notify(stopChannel, os.Interrupt)
 	c := chi.NewRouter()
 	c.Use(middleware.Logger)
+	c.Use(limitRequestBody)
 	c.Get("/", homeHandlers)
-	c.Mount("/todo", todoHandlers())
+	c.Mount("/auth", app.authHandlers())
+	c.Mount("/todo", app.todoHandlers())
 
 	server := http.Server{
-		Addr:         port,
+		Addr:         cfg.HTTPAddr,
 		Handler:      c,
-		ReadTimeout:  60 * time.Second,
-		WriteTimeout: 60 * time.Second,
-		IdleTimeout:  60 * time.Second,
+		ReadTimeout:  cfg.ReadTimeout,
+		WriteTimeout: cfg.WriteTimeout,
+		IdleTimeout:  cfg.IdleTimeout,
 	}
 	go func() {
-		log.Println("listening on port", port)
+		log.Println("listening on port", cfg.HTTPAddr)
 		if err := server.ListenAndServe(); err != nil {
 			log.Printf("listening:%s\n", err)
 		}
 	}()
 
 	<-stopChannel
-	log.Panicln("shutting down server...")
-	// cretae context to send server shutdown
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
-	server.Shutdown(ctx)
+	log.Println("shutting down server...")
+	close(hubStop)
+	ctx, cancel = context.WithTimeout(context.Background(), cfg.ShutdownTimeout)
 	defer cancel()
+	server.Shutdown(ctx)
 	log.Println("server gracefuly stopped!")
 
 }
 
-func todoHandlers() http.Handler {
+// watchChangeStream publishes a MongoDB change stream directly to hub,
+// bypassing the handlers. Unlike the handlers broadcasting after their
+// own writes, this keeps every API instance's clients in sync even
+// when the write that triggered the event happened on a different
+// instance.
+func watchChangeStream(store *storage.MongoStore, hub *realtime.Hub) {
+	for {
+		err := store.Watch(context.Background(), func(change storage.ChangeEvent) {
+			eventType, ok := changeStreamEventType(change.OperationType)
+			if !ok {
+				return
+			}
+			hub.Broadcast(realtime.Event{
+				Type:    eventType,
+				OwnerID: change.Todo.OwnerID,
+				Todo:    toAPITodo(change.Todo),
+			})
+		})
+		log.Printf("change stream watcher stopped: %s, restarting\n", err)
+		time.Sleep(time.Second)
+	}
+}
+
+func changeStreamEventType(operationType string) (string, bool) {
+	switch operationType {
+	case "insert":
+		return realtime.EventCreated, true
+	case "update", "replace":
+		return realtime.EventUpdated, true
+	case "delete":
+		return realtime.EventDeleted, true
+	default:
+		return "", false
+	}
+}
+
+func (a *application) todoHandlers() http.Handler {
 	rTodo := chi.NewRouter()
+	// The stream endpoint authenticates via ?token= instead of the
+	// Authorization header (browsers can't set custom headers on the
+	// WebSocket handshake), so it sits outside the auth.Middleware group.
+	rTodo.Get("/stream", a.streamTodos)
 	rTodo.Group(func(r chi.Router) {
-		r.Get("/", fetchTodo)
-		r.Post("/", createTodo)
-		r.Put("/{id}", updateTodo)
-		r.Delete("/{id}", deleteTodo)
+		r.Use(auth.Middleware(a.jwtSecret))
+		r.Get("/", a.fetchTodo)
+		r.Get("/{id}", a.fetchTodoByID)
+		r.Post("/", a.createTodo)
+		r.Put("/{id}", a.updateTodo)
+		r.Delete("/{id}", a.deleteTodo)
 	})
 
 	return rTodo
 
 }
 
+// streamTodos upgrades to a WebSocket and pushes {event, todo} messages
+// for every create/update/delete belonging to the caller.
+func (a *application) streamTodos(w http.ResponseWriter, r *http.Request) {
+	userID, err := auth.ParseToken(r.URL.Query().Get("token"), a.jwtSecret)
+	if err != nil {
+		http.Error(w, "invalid or expired token", http.StatusUnauthorized)
+		return
+	}
+	if err := realtime.ServeWS(a.hub, userID, w, r); err != nil {
+		log.Printf("stream upgrade failed: %s\n", err)
+	}
+}
+
 func checkErr(err error) {
 	if err != nil {
 		log.Fatal(err)
@@ -108,116 +195,200 @@ func homeHandlers(w http.ResponseWriter, r *http.Request) {
 	checkErr(err)
 }
 
-func fetchTodo(w http.ResponseWriter, r *http.Request) {
-	todos := []todoModel{}
+func (a *application) fetchTodo(w http.ResponseWriter, r *http.Request) {
+	filter, pagination := parseListParams(r)
+	filter.OwnerID, _ = auth.UserIDFromContext(r.Context())
 
-	if err := db.C(collectionName).Find(bson.M{}).All(&todos); err != nil {
-		rnd.JSON(w, http.StatusProcessing, renderer.M{
-			"message": "failed to fetch todo",
-			"error":   err,
-		})
+	page, err := a.store.List(r.Context(), filter, pagination)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "internal_error", "failed to fetch todo", nil)
 		return
 	}
-	todoList := []todo{}
 
-	for _, t := range todos {
-		todoList = append(todoList, todo{
-			ID:        bson.ObjectId(t.ID.Hex()),
-			Title:     t.Title,
-			Completed: t.Completed,
-			CreatedAt: t.CreatedAt,
-		})
+	todoList := make([]todo, 0, len(page.Todos))
+	for _, t := range page.Todos {
+		todoList = append(todoList, toAPITodo(t))
 	}
 	rnd.JSON(w, http.StatusOK, renderer.M{
 		"data": todoList,
+		"meta": renderer.M{
+			"total":  page.Total,
+			"limit":  pagination.Limit,
+			"offset": pagination.Offset,
+		},
 	})
 }
 
-func createTodo(w http.ResponseWriter, r *http.Request) {
-	var t todo
+func (a *application) fetchTodoByID(w http.ResponseWriter, r *http.Request) {
+	id := strings.TrimSpace(chi.URLParam(r, "id"))
 
-	if err := json.NewDecoder(r.Body).Decode(&t); err != nil {
-		rnd.JSON(w, http.StatusProcessing, err)
+	t, err := a.ownedTodo(r, id)
+	if err != nil {
+		writeTodoStoreErr(w, err, "failed to fetch todo")
 		return
 	}
+	rnd.JSON(w, http.StatusOK, renderer.M{
+		"data": toAPITodo(*t),
+	})
+}
 
-	if t.Title == "" {
-		rnd.JSON(w, http.StatusBadRequest, renderer.M{
-			"message": "title required",
-		})
+// parseListParams reads ?limit=, ?offset=/?page=, ?completed=, ?q= and
+// ?sort= off the query string, falling back to sane defaults for
+// anything missing or malformed.
+func parseListParams(r *http.Request) (storage.Filter, storage.Pagination) {
+	q := r.URL.Query()
+
+	filter := storage.Filter{Query: strings.TrimSpace(q.Get("q"))}
+	if v := q.Get("completed"); v != "" {
+		if completed, err := strconv.ParseBool(v); err == nil {
+			filter.Completed = &completed
+		}
 	}
 
-	tm := todoModel{
-		ID:        t.ID,
+	limit := int64(defaultLimit)
+	if v, err := strconv.ParseInt(q.Get("limit"), 10, 64); err == nil && v > 0 {
+		limit = v
+	}
+	if limit > maxLimit {
+		limit = maxLimit
+	}
+
+	offset := int64(0)
+	if v, err := strconv.ParseInt(q.Get("offset"), 10, 64); err == nil && v > 0 {
+		offset = v
+	} else if v, err := strconv.ParseInt(q.Get("page"), 10, 64); err == nil && v > 1 {
+		offset = (v - 1) * limit
+	}
+
+	sort := storage.Sort(q.Get("sort"))
+	switch sort {
+	case storage.SortCreatedAtAsc, storage.SortCreatedAtDesc, storage.SortTitle:
+	default:
+		sort = storage.SortCreatedAtAsc
+	}
+
+	return filter, storage.Pagination{Limit: limit, Offset: offset, Sort: sort}
+}
+
+func (a *application) createTodo(w http.ResponseWriter, r *http.Request) {
+	var t todo
+	if !decodeJSON(w, r, &t) {
+		return
+	}
+	if !validateStruct(w, &t) {
+		return
+	}
+
+	userID, _ := auth.UserIDFromContext(r.Context())
+	st := storage.Todo{
+		OwnerID:   userID,
 		Title:     t.Title,
 		Completed: false,
 		CreatedAt: time.Now(),
 	}
 
-	if err := db.C(collectionName).Insert(&tm); err != nil {
-		rnd.JSON(w, http.StatusProcessing, renderer.M{
-			"message": "failed to save todo",
-			"error":   err,
-		})
+	if err := a.store.Create(r.Context(), &st); err != nil {
+		writeError(w, http.StatusInternalServerError, "internal_error", "failed to save todo", nil)
 		return
 	}
+	a.publish(realtime.Event{Type: realtime.EventCreated, OwnerID: st.OwnerID, Todo: toAPITodo(st)})
 	rnd.JSON(w, http.StatusCreated, renderer.M{
 		"message": "todo created successfully",
-		"todo_id": tm.ID.Hex(),
+		"todo_id": st.ID,
 	})
 }
 
-func deleteTodo(w http.ResponseWriter, r *http.Request) {
+func (a *application) deleteTodo(w http.ResponseWriter, r *http.Request) {
 	id := strings.TrimSpace(chi.URLParam(r, "id"))
 
-	if !bson.IsObjectIdHex(id) {
-		rnd.JSON(w, http.StatusProcessing, renderer.M{
-			"message": "invalid id",
-		})
+	existing, err := a.ownedTodo(r, id)
+	if err != nil {
+		writeTodoStoreErr(w, err, "failed delete todo")
 		return
 	}
-	if err := db.C(collectionName).RemoveId(bson.ObjectIdHex(id)); err != nil {
-		rnd.JSON(w, http.StatusProcessing, renderer.M{
-			"message": "failed delete todo",
-		})
+
+	if err := a.store.Delete(r.Context(), id); err != nil {
+		writeTodoStoreErr(w, err, "failed delete todo")
 		return
 	}
+	a.publish(realtime.Event{Type: realtime.EventDeleted, OwnerID: existing.OwnerID, Todo: toAPITodo(*existing)})
 	rnd.JSON(w, http.StatusNoContent, renderer.M{
 		"message": "delete successfully",
 	})
 }
 
-func updateTodo(w http.ResponseWriter, r *http.Request) {
+func (a *application) updateTodo(w http.ResponseWriter, r *http.Request) {
 	id := strings.TrimSpace(chi.URLParam(r, "id"))
 
-	if !bson.IsObjectIdHex(id) {
-		rnd.JSON(w, http.StatusBadRequest, renderer.M{
-			"message": "invalid id",
-		})
+	existing, err := a.ownedTodo(r, id)
+	if err != nil {
+		writeTodoStoreErr(w, err, "failed update todo")
 		return
 	}
+
 	var t todo
+	if !decodeJSON(w, r, &t) {
+		return
+	}
+	if !validateStruct(w, &t) {
+		return
+	}
 
-	if err := json.NewDecoder(r.Body).Decode(&t); err != nil {
-		rnd.JSON(w, http.StatusProcessing, err)
+	st := storage.Todo{Title: t.Title, Completed: t.Completed}
+	if err := a.store.Update(r.Context(), id, &st); err != nil {
+		writeTodoStoreErr(w, err, "failed update todo")
 		return
 	}
+	a.publish(realtime.Event{
+		Type:    realtime.EventUpdated,
+		OwnerID: existing.OwnerID,
+		Todo:    toAPITodo(storage.Todo{ID: id, OwnerID: existing.OwnerID, Title: st.Title, Completed: st.Completed, CreatedAt: existing.CreatedAt}),
+	})
+	rnd.JSON(w, http.StatusOK, renderer.M{
+		"message": "todo updated successfully",
+	})
+}
 
-	if t.Title == "" {
-		rnd.JSON(w, http.StatusProcessing, renderer.M{
-			"message": "todo required",
-		})
+// publish broadcasts event to hub, unless watchChangeStream is already
+// doing that for this process (see Config.RealtimeSource): publishing
+// from both places would deliver every mutation to same-instance
+// clients twice.
+func (a *application) publish(event realtime.Event) {
+	if a.realtimeSource == realtimeSourceChangeStream {
 		return
 	}
-	if err := db.C(collectionName).
-		Update(
-			bson.M{"_id": string(bson.ObjectIdHex(id))},
-			bson.M{"title": t.Title, "completed": t.Completed},
-		); err != nil {
-		rnd.JSON(w, http.StatusOK, renderer.M{
-			"message": "failed update todo",
-			"error":   err,
-		})
+	a.hub.Broadcast(event)
+}
+
+// ownedTodo fetches the todo with id and confirms it belongs to the
+// authenticated user, returning storage.ErrNotFound if either check
+// fails so ownership mismatches aren't distinguishable from a missing
+// todo.
+func (a *application) ownedTodo(r *http.Request, id string) (*storage.Todo, error) {
+	t, err := a.store.GetByID(r.Context(), id)
+	if err != nil {
+		return nil, err
+	}
+	userID, _ := auth.UserIDFromContext(r.Context())
+	if t.OwnerID != userID {
+		return nil, storage.ErrNotFound
+	}
+	return t, nil
+}
+
+func writeTodoStoreErr(w http.ResponseWriter, err error, message string) {
+	if err == storage.ErrNotFound {
+		writeError(w, http.StatusNotFound, "not_found", "todo not found", nil)
 		return
 	}
+	writeError(w, http.StatusInternalServerError, "internal_error", message, nil)
+}
+
+func toAPITodo(t storage.Todo) todo {
+	return todo{
+		ID:        t.ID,
+		Title:     t.Title,
+		Completed: t.Completed,
+		CreatedAt: t.CreatedAt,
+	}
 }
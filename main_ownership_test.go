@@ -0,0 +1,173 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jalal-akbar/todo/auth"
+)
+
+// newRunningTestApp is newTestApp plus a started Hub, since Broadcast
+// blocks until something is draining its events channel and the
+// handlers under test here (create/update/delete) all call it.
+func newRunningTestApp(t *testing.T) *application {
+	t.Helper()
+	app := newTestApp()
+	stop := make(chan struct{})
+	go app.hub.Run(stop)
+	t.Cleanup(func() { close(stop) })
+	return app
+}
+
+func tokenFor(t *testing.T, app *application, userID string) string {
+	t.Helper()
+	token, err := auth.NewToken(userID, app.jwtSecret, time.Hour)
+	if err != nil {
+		t.Fatalf("NewToken() error = %v", err)
+	}
+	return token
+}
+
+func createTodoAs(t *testing.T, router http.Handler, token, title string) string {
+	t.Helper()
+	r := httptest.NewRequest("POST", "/", strings.NewReader(`{"title":"`+title+`"}`))
+	r.Header.Set("Authorization", "Bearer "+token)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, r)
+	if w.Code != http.StatusCreated {
+		t.Fatalf("create status = %d, body = %s", w.Code, w.Body.String())
+	}
+	var body struct {
+		TodoID string `json:"todo_id"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &body); err != nil {
+		t.Fatalf("decode create response: %v", err)
+	}
+	return body.TodoID
+}
+
+func TestTodoHandlersRejectCrossUserAccess(t *testing.T) {
+	app := newRunningTestApp(t)
+	router := app.todoHandlers()
+
+	aliceToken := tokenFor(t, app, "alice")
+	bobToken := tokenFor(t, app, "bob")
+	todoID := createTodoAs(t, router, aliceToken, "alice's secret")
+
+	cases := []struct {
+		method string
+		path   string
+		body   string
+	}{
+		{"GET", "/" + todoID, ""},
+		{"PUT", "/" + todoID, `{"title":"hijacked"}`},
+		{"DELETE", "/" + todoID, ""},
+	}
+
+	for _, c := range cases {
+		var body *strings.Reader
+		if c.body != "" {
+			body = strings.NewReader(c.body)
+		} else {
+			body = strings.NewReader("")
+		}
+		r := httptest.NewRequest(c.method, c.path, body)
+		r.Header.Set("Authorization", "Bearer "+bobToken)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, r)
+
+		if w.Code != http.StatusNotFound {
+			t.Errorf("%s %s as bob: status = %d, want 404 (not leaked); body = %s", c.method, c.path, w.Code, w.Body.String())
+		}
+	}
+
+	r := httptest.NewRequest("GET", "/"+todoID, nil)
+	r.Header.Set("Authorization", "Bearer "+aliceToken)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, r)
+	if w.Code != http.StatusOK {
+		t.Errorf("GET /todo/%s as alice: status = %d, want 200", todoID, w.Code)
+	}
+}
+
+func TestFetchTodoOnlyReturnsOwnTodos(t *testing.T) {
+	app := newRunningTestApp(t)
+	router := app.todoHandlers()
+
+	aliceToken := tokenFor(t, app, "alice")
+	bobToken := tokenFor(t, app, "bob")
+	createTodoAs(t, router, aliceToken, "alice 1")
+	createTodoAs(t, router, aliceToken, "alice 2")
+	createTodoAs(t, router, bobToken, "bob 1")
+
+	r := httptest.NewRequest("GET", "/", nil)
+	r.Header.Set("Authorization", "Bearer "+aliceToken)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, r)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("status = %d, body = %s", w.Code, w.Body.String())
+	}
+
+	var resp struct {
+		Data []todo `json:"data"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("decode response: %v", err)
+	}
+	if len(resp.Data) != 2 {
+		t.Fatalf("len(Data) = %d, want 2 (alice's todos only)", len(resp.Data))
+	}
+	for _, td := range resp.Data {
+		if !strings.HasPrefix(td.Title, "alice") {
+			t.Errorf("fetchTodo leaked a non-alice todo: %+v", td)
+		}
+	}
+}
+
+func TestMiddlewareRejectsInvalidBearerTokens(t *testing.T) {
+	app := newRunningTestApp(t)
+	router := app.todoHandlers()
+
+	expired, err := auth.NewToken("alice", app.jwtSecret, -time.Hour)
+	if err != nil {
+		t.Fatalf("NewToken() error = %v", err)
+	}
+
+	cases := []struct {
+		name   string
+		header string
+	}{
+		{"missing header", ""},
+		{"malformed, no Bearer prefix", "alice-token"},
+		{"garbage token", "Bearer not-a-real-jwt"},
+		{"expired token", "Bearer " + expired},
+		{"wrong secret", "Bearer " + mustToken(t, "alice", "wrong-secret")},
+	}
+
+	for _, c := range cases {
+		r := httptest.NewRequest("GET", "/", nil)
+		if c.header != "" {
+			r.Header.Set("Authorization", c.header)
+		}
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, r)
+
+		if w.Code != http.StatusUnauthorized {
+			t.Errorf("%s: status = %d, want 401", c.name, w.Code)
+		}
+	}
+}
+
+func mustToken(t *testing.T, userID, secret string) string {
+	t.Helper()
+	token, err := auth.NewToken(userID, secret, time.Hour)
+	if err != nil {
+		t.Fatalf("NewToken() error = %v", err)
+	}
+	return token
+}
@@ -0,0 +1,41 @@
+package main
+
+import (
+	"net/http/httptest"
+	"testing"
+
+	"github.com/jalal-akbar/todo/storage"
+)
+
+func TestParseListParamsDefaults(t *testing.T) {
+	r := httptest.NewRequest("GET", "/todo", nil)
+	filter, pagination := parseListParams(r)
+
+	if filter.Completed != nil || filter.Query != "" {
+		t.Errorf("filter = %+v, want zero value", filter)
+	}
+	if pagination.Limit != defaultLimit || pagination.Offset != 0 {
+		t.Errorf("pagination = %+v, want limit=%d offset=0", pagination, defaultLimit)
+	}
+}
+
+func TestParseListParams(t *testing.T) {
+	r := httptest.NewRequest("GET", "/todo?limit=500&page=2&completed=true&q=milk&sort=title", nil)
+	filter, pagination := parseListParams(r)
+
+	if filter.Completed == nil || !*filter.Completed {
+		t.Errorf("filter.Completed = %v, want true", filter.Completed)
+	}
+	if filter.Query != "milk" {
+		t.Errorf("filter.Query = %q, want %q", filter.Query, "milk")
+	}
+	if pagination.Limit != maxLimit {
+		t.Errorf("pagination.Limit = %d, want capped at %d", pagination.Limit, maxLimit)
+	}
+	if pagination.Offset != maxLimit {
+		t.Errorf("pagination.Offset = %d, want %d (page 2)", pagination.Offset, maxLimit)
+	}
+	if pagination.Sort != storage.SortTitle {
+		t.Errorf("pagination.Sort = %q, want %q", pagination.Sort, storage.SortTitle)
+	}
+}
@@ -0,0 +1,97 @@
+package realtime
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+const (
+	writeWait  = 10 * time.Second
+	pongWait   = 60 * time.Second
+	pingPeriod = pongWait * 9 / 10
+)
+
+var upgrader = websocket.Upgrader{
+	ReadBufferSize:  1024,
+	WriteBufferSize: 1024,
+	// The API and the front-end are not guaranteed to share an origin
+	// (e.g. local dev on a different port), so origin is intentionally
+	// not checked here; put this behind a reverse proxy that enforces
+	// it in production.
+	CheckOrigin: func(r *http.Request) bool { return true },
+}
+
+// Client is a single WebSocket connection subscribed to one user's
+// todo events.
+type Client struct {
+	hub     *Hub
+	conn    *websocket.Conn
+	ownerID string
+	send    chan []byte
+}
+
+// ServeWS upgrades r to a WebSocket, registers a Client scoped to
+// ownerID with hub, and blocks until the connection closes.
+func ServeWS(hub *Hub, ownerID string, w http.ResponseWriter, r *http.Request) error {
+	conn, err := upgrader.Upgrade(w, r, nil)
+	if err != nil {
+		return err
+	}
+
+	c := &Client{hub: hub, conn: conn, ownerID: ownerID, send: make(chan []byte, 16)}
+	hub.register <- c
+
+	go c.readPump()
+	c.writePump()
+	return nil
+}
+
+// readPump discards any client-sent frames but is required so the
+// websocket library processes control frames (ping/pong, close) and
+// notices a dropped connection.
+func (c *Client) readPump() {
+	defer func() {
+		c.hub.unregister <- c
+		c.conn.Close()
+	}()
+
+	c.conn.SetReadDeadline(time.Now().Add(pongWait))
+	c.conn.SetPongHandler(func(string) error {
+		c.conn.SetReadDeadline(time.Now().Add(pongWait))
+		return nil
+	})
+	for {
+		if _, _, err := c.conn.ReadMessage(); err != nil {
+			return
+		}
+	}
+}
+
+func (c *Client) writePump() {
+	ticker := time.NewTicker(pingPeriod)
+	defer func() {
+		ticker.Stop()
+		c.conn.Close()
+	}()
+
+	for {
+		select {
+		case message, ok := <-c.send:
+			c.conn.SetWriteDeadline(time.Now().Add(writeWait))
+			if !ok {
+				c.conn.WriteMessage(websocket.CloseMessage, []byte{})
+				return
+			}
+			if err := c.conn.WriteMessage(websocket.TextMessage, message); err != nil {
+				return
+			}
+		case <-ticker.C:
+			c.conn.SetWriteDeadline(time.Now().Add(writeWait))
+			if err := c.conn.WriteMessage(websocket.PingMessage, nil); err != nil {
+				return
+			}
+		}
+	}
+}
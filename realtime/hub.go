@@ -0,0 +1,85 @@
+// Package realtime fans out todo mutations to connected WebSocket
+// clients so front-ends can build a live UI without polling.
+package realtime
+
+import "encoding/json"
+
+// Event types broadcast over a todo's live stream.
+const (
+	EventCreated = "created"
+	EventUpdated = "updated"
+	EventDeleted = "deleted"
+)
+
+// Event is a single todo mutation, scoped to the user it belongs to so
+// the Hub only delivers it to that user's connections.
+type Event struct {
+	Type    string
+	OwnerID string
+	Todo    interface{}
+}
+
+func (e Event) message() ([]byte, error) {
+	return json.Marshal(struct {
+		Event string      `json:"event"`
+		Todo  interface{} `json:"todo"`
+	}{Event: e.Type, Todo: e.Todo})
+}
+
+// Hub keeps track of connected clients and fans out events to the ones
+// belonging to the right user. Handlers publish to it after a
+// successful write; Run must be started once in its own goroutine.
+type Hub struct {
+	register   chan *Client
+	unregister chan *Client
+	events     chan Event
+	clients    map[*Client]bool
+}
+
+// NewHub returns a Hub with no connected clients. Call Run to start it.
+func NewHub() *Hub {
+	return &Hub{
+		register:   make(chan *Client),
+		unregister: make(chan *Client),
+		events:     make(chan Event),
+		clients:    make(map[*Client]bool),
+	}
+}
+
+// Run drives the Hub's event loop until stop is closed.
+func (h *Hub) Run(stop <-chan struct{}) {
+	for {
+		select {
+		case c := <-h.register:
+			h.clients[c] = true
+		case c := <-h.unregister:
+			if _, ok := h.clients[c]; ok {
+				delete(h.clients, c)
+				close(c.send)
+			}
+		case event := <-h.events:
+			message, err := event.message()
+			if err != nil {
+				continue
+			}
+			for c := range h.clients {
+				if c.ownerID != event.OwnerID {
+					continue
+				}
+				select {
+				case c.send <- message:
+				default:
+					delete(h.clients, c)
+					close(c.send)
+				}
+			}
+		case <-stop:
+			return
+		}
+	}
+}
+
+// Broadcast publishes event to every client owned by event.OwnerID.
+func (h *Hub) Broadcast(event Event) {
+	h.events <- event
+}
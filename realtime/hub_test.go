@@ -0,0 +1,35 @@
+package realtime
+
+import (
+	"testing"
+	"time"
+)
+
+func TestHubBroadcastScopesToOwner(t *testing.T) {
+	hub := NewHub()
+	stop := make(chan struct{})
+	defer close(stop)
+	go hub.Run(stop)
+
+	alice := &Client{hub: hub, ownerID: "alice", send: make(chan []byte, 1)}
+	bob := &Client{hub: hub, ownerID: "bob", send: make(chan []byte, 1)}
+	hub.register <- alice
+	hub.register <- bob
+
+	hub.Broadcast(Event{Type: EventCreated, OwnerID: "alice", Todo: map[string]string{"title": "hi"}})
+
+	select {
+	case msg := <-alice.send:
+		if string(msg) == "" {
+			t.Error("alice received an empty message")
+		}
+	case <-time.After(time.Second):
+		t.Fatal("alice did not receive the event")
+	}
+
+	select {
+	case <-bob.send:
+		t.Fatal("bob should not have received alice's event")
+	case <-time.After(100 * time.Millisecond):
+	}
+}
@@ -0,0 +1,108 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// MemoryStore is an in-process TodoStore backed by a map. It exists so
+// handlers can be exercised in tests without a running MongoDB.
+type MemoryStore struct {
+	mu     sync.Mutex
+	todos  map[string]Todo
+	nextID int
+}
+
+// NewMemoryStore returns an empty MemoryStore.
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{todos: map[string]Todo{}}
+}
+
+func (s *MemoryStore) Create(_ context.Context, t *Todo) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.nextID++
+	t.ID = fmt.Sprintf("%024x", s.nextID)
+	s.todos[t.ID] = *t
+	return nil
+}
+
+func (s *MemoryStore) GetByID(_ context.Context, id string) (*Todo, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	t, ok := s.todos[id]
+	if !ok {
+		return nil, ErrNotFound
+	}
+	return &t, nil
+}
+
+func (s *MemoryStore) List(_ context.Context, filter Filter, pagination Pagination) (Page, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	matched := []Todo{}
+	for _, t := range s.todos {
+		if filter.OwnerID != "" && t.OwnerID != filter.OwnerID {
+			continue
+		}
+		if filter.Completed != nil && t.Completed != *filter.Completed {
+			continue
+		}
+		if q := strings.TrimSpace(filter.Query); q != "" && !strings.Contains(strings.ToLower(t.Title), strings.ToLower(q)) {
+			continue
+		}
+		matched = append(matched, t)
+	}
+
+	switch pagination.Sort {
+	case SortCreatedAtDesc:
+		sort.Slice(matched, func(i, j int) bool { return matched[i].CreatedAt.After(matched[j].CreatedAt) })
+	case SortTitle:
+		sort.Slice(matched, func(i, j int) bool { return matched[i].Title < matched[j].Title })
+	default:
+		sort.Slice(matched, func(i, j int) bool { return matched[i].CreatedAt.Before(matched[j].CreatedAt) })
+	}
+
+	total := int64(len(matched))
+	start := pagination.Offset
+	if start > int64(len(matched)) {
+		start = int64(len(matched))
+	}
+	end := int64(len(matched))
+	if pagination.Limit > 0 && start+pagination.Limit < end {
+		end = start + pagination.Limit
+	}
+
+	return Page{Todos: matched[start:end], Total: total}, nil
+}
+
+func (s *MemoryStore) Update(_ context.Context, id string, t *Todo) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	existing, ok := s.todos[id]
+	if !ok {
+		return ErrNotFound
+	}
+	existing.Title = t.Title
+	existing.Completed = t.Completed
+	s.todos[id] = existing
+	return nil
+}
+
+func (s *MemoryStore) Delete(_ context.Context, id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.todos[id]; !ok {
+		return ErrNotFound
+	}
+	delete(s.todos, id)
+	return nil
+}
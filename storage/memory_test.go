@@ -0,0 +1,71 @@
+package storage
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestMemoryStoreCRUD(t *testing.T) {
+	ctx := context.Background()
+	s := NewMemoryStore()
+
+	created := Todo{Title: "write tests", CreatedAt: time.Now()}
+	if err := s.Create(ctx, &created); err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+	if created.ID == "" {
+		t.Fatal("Create() did not assign an ID")
+	}
+
+	got, err := s.GetByID(ctx, created.ID)
+	if err != nil {
+		t.Fatalf("GetByID() error = %v", err)
+	}
+	if got.Title != "write tests" {
+		t.Errorf("GetByID() title = %q, want %q", got.Title, "write tests")
+	}
+
+	update := Todo{Title: "write more tests", Completed: true}
+	if err := s.Update(ctx, created.ID, &update); err != nil {
+		t.Fatalf("Update() error = %v", err)
+	}
+	got, _ = s.GetByID(ctx, created.ID)
+	if !got.Completed || got.Title != "write more tests" {
+		t.Errorf("Update() did not persist, got %+v", got)
+	}
+
+	if err := s.Delete(ctx, created.ID); err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+	if _, err := s.GetByID(ctx, created.ID); err != ErrNotFound {
+		t.Errorf("GetByID() after delete error = %v, want ErrNotFound", err)
+	}
+}
+
+func TestMemoryStoreGetByIDNotFound(t *testing.T) {
+	s := NewMemoryStore()
+	if _, err := s.GetByID(context.Background(), "missing"); err != ErrNotFound {
+		t.Errorf("GetByID() error = %v, want ErrNotFound", err)
+	}
+}
+
+func TestMemoryStoreList(t *testing.T) {
+	ctx := context.Background()
+	s := NewMemoryStore()
+
+	for _, title := range []string{"buy milk", "write report", "buy bread"} {
+		todo := Todo{Title: title, CreatedAt: time.Now()}
+		if err := s.Create(ctx, &todo); err != nil {
+			t.Fatalf("Create() error = %v", err)
+		}
+	}
+
+	page, err := s.List(ctx, Filter{Query: "buy"}, Pagination{})
+	if err != nil {
+		t.Fatalf("List() error = %v", err)
+	}
+	if page.Total != 2 {
+		t.Errorf("List() total = %d, want 2", page.Total)
+	}
+}
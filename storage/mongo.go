@@ -0,0 +1,183 @@
+package storage
+
+import (
+	"context"
+	"regexp"
+	"strings"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// mongoTodo is the bson-tagged shape stored in MongoDB. It stays
+// private to this file so the rest of the app only ever sees Todo.
+type mongoTodo struct {
+	ID        primitive.ObjectID `bson:"_id,omitempty"`
+	OwnerID   string             `bson:"ownerId"`
+	Title     string             `bson:"title"`
+	Completed bool               `bson:"completed"`
+	CreatedAt time.Time          `bson:"createdAt"`
+}
+
+// Connect dials uri and pings the server so connection problems are
+// caught at startup rather than on the first request. The returned
+// client is shared across all Mongo-backed stores.
+func Connect(ctx context.Context, uri string) (*mongo.Client, error) {
+	client, err := mongo.Connect(ctx, options.Client().ApplyURI(uri))
+	if err != nil {
+		return nil, err
+	}
+	if err := client.Ping(ctx, nil); err != nil {
+		return nil, err
+	}
+	return client, nil
+}
+
+// MongoStore is a TodoStore backed by the official MongoDB driver.
+type MongoStore struct {
+	collection *mongo.Collection
+}
+
+// NewMongoStore returns a MongoStore backed by dbName.collectionName on
+// an already-connected client.
+func NewMongoStore(client *mongo.Client, dbName, collectionName string) *MongoStore {
+	return &MongoStore{
+		collection: client.Database(dbName).Collection(collectionName),
+	}
+}
+
+func fromMongoTodo(mt mongoTodo) Todo {
+	return Todo{
+		ID:        mt.ID.Hex(),
+		OwnerID:   mt.OwnerID,
+		Title:     mt.Title,
+		Completed: mt.Completed,
+		CreatedAt: mt.CreatedAt,
+	}
+}
+
+func (s *MongoStore) Create(ctx context.Context, t *Todo) error {
+	mt := mongoTodo{
+		ID:        primitive.NewObjectID(),
+		OwnerID:   t.OwnerID,
+		Title:     t.Title,
+		Completed: t.Completed,
+		CreatedAt: t.CreatedAt,
+	}
+	if _, err := s.collection.InsertOne(ctx, mt); err != nil {
+		return err
+	}
+	t.ID = mt.ID.Hex()
+	return nil
+}
+
+func (s *MongoStore) GetByID(ctx context.Context, id string) (*Todo, error) {
+	oid, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, ErrNotFound
+	}
+	var mt mongoTodo
+	if err := s.collection.FindOne(ctx, bson.M{"_id": oid}).Decode(&mt); err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, ErrNotFound
+		}
+		return nil, err
+	}
+	result := fromMongoTodo(mt)
+	return &result, nil
+}
+
+func (s *MongoStore) List(ctx context.Context, filter Filter, pagination Pagination) (Page, error) {
+	query := bson.M{}
+	if filter.OwnerID != "" {
+		query["ownerId"] = filter.OwnerID
+	}
+	if filter.Completed != nil {
+		query["completed"] = *filter.Completed
+	}
+	if q := strings.TrimSpace(filter.Query); q != "" {
+		query["title"] = bson.M{"$regex": primitive.Regex{Pattern: regexp.QuoteMeta(q), Options: "i"}}
+	}
+
+	total, err := s.collection.CountDocuments(ctx, query)
+	if err != nil {
+		return Page{}, err
+	}
+
+	opts := options.Find().SetSkip(pagination.Offset)
+	if pagination.Limit > 0 {
+		opts.SetLimit(pagination.Limit)
+	}
+	if sort := sortDoc(pagination.Sort); sort != nil {
+		opts.SetSort(sort)
+	}
+
+	cursor, err := s.collection.Find(ctx, query, opts)
+	if err != nil {
+		return Page{}, err
+	}
+	defer cursor.Close(ctx)
+
+	todos := []Todo{}
+	for cursor.Next(ctx) {
+		var mt mongoTodo
+		if err := cursor.Decode(&mt); err != nil {
+			return Page{}, err
+		}
+		todos = append(todos, fromMongoTodo(mt))
+	}
+	if err := cursor.Err(); err != nil {
+		return Page{}, err
+	}
+
+	return Page{Todos: todos, Total: total}, nil
+}
+
+func sortDoc(s Sort) bson.D {
+	switch s {
+	case SortCreatedAtAsc:
+		return bson.D{{Key: "createdAt", Value: 1}}
+	case SortCreatedAtDesc:
+		return bson.D{{Key: "createdAt", Value: -1}}
+	case SortTitle:
+		return bson.D{{Key: "title", Value: 1}}
+	default:
+		return nil
+	}
+}
+
+func (s *MongoStore) Update(ctx context.Context, id string, t *Todo) error {
+	oid, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return ErrNotFound
+	}
+	res, err := s.collection.UpdateOne(ctx,
+		bson.M{"_id": oid},
+		bson.M{"$set": bson.M{"title": t.Title, "completed": t.Completed}},
+	)
+	if err != nil {
+		return err
+	}
+	if res.MatchedCount == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+func (s *MongoStore) Delete(ctx context.Context, id string) error {
+	oid, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return ErrNotFound
+	}
+	res, err := s.collection.DeleteOne(ctx, bson.M{"_id": oid})
+	if err != nil {
+		return err
+	}
+	if res.DeletedCount == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
@@ -0,0 +1,74 @@
+// Package storage defines the persistence boundary for todos so that
+// HTTP handlers depend on an interface instead of a concrete database
+// driver.
+package storage
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// ErrNotFound is returned by TodoStore's GetByID, Update and Delete when
+// no todo matches the given id, and by UserStore's GetByEmail and
+// GetByID when no user matches.
+var ErrNotFound = errors.New("storage: todo not found")
+
+// Todo is the storage-layer representation of a todo item. It is kept
+// free of any driver-specific tags (bson, json, ...) so the same type
+// can be shared across store implementations.
+type Todo struct {
+	ID        string
+	OwnerID   string
+	Title     string
+	Completed bool
+	CreatedAt time.Time
+}
+
+// Filter narrows down the todos returned by List.
+type Filter struct {
+	// OwnerID, when non-empty, restricts the results to todos owned by
+	// that user.
+	OwnerID string
+	// Completed, when non-nil, restricts the results to todos whose
+	// Completed field matches the pointed-to value.
+	Completed *bool
+	// Query, when non-empty, matches todos whose title contains it
+	// (case-insensitive substring match).
+	Query string
+}
+
+// Sort identifies the field/direction List results are ordered by.
+type Sort string
+
+const (
+	SortCreatedAtAsc  Sort = "createdAt"
+	SortCreatedAtDesc Sort = "-createdAt"
+	SortTitle         Sort = "title"
+)
+
+// Pagination controls how many todos List returns and from where.
+type Pagination struct {
+	Limit  int64
+	Offset int64
+	Sort   Sort
+}
+
+// Page is the result of a List call: the todos for the requested page
+// plus the total number of todos matching the filter (ignoring
+// pagination), so callers can build "total/limit/offset" style
+// metadata.
+type Page struct {
+	Todos []Todo
+	Total int64
+}
+
+// TodoStore is the persistence contract handlers depend on. Concrete
+// implementations live alongside this package (MongoStore, MemoryStore).
+type TodoStore interface {
+	Create(ctx context.Context, t *Todo) error
+	GetByID(ctx context.Context, id string) (*Todo, error)
+	List(ctx context.Context, filter Filter, pagination Pagination) (Page, error)
+	Update(ctx context.Context, id string, t *Todo) error
+	Delete(ctx context.Context, id string) error
+}
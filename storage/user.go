@@ -0,0 +1,25 @@
+package storage
+
+import (
+	"context"
+	"errors"
+)
+
+// ErrUserExists is returned by UserStore.Create when the email is
+// already registered.
+var ErrUserExists = errors.New("storage: user already exists")
+
+// User is a registered account. PasswordHash holds a bcrypt hash, never
+// the plaintext password.
+type User struct {
+	ID           string
+	Email        string
+	PasswordHash string
+}
+
+// UserStore is the persistence contract for accounts.
+type UserStore interface {
+	Create(ctx context.Context, u *User) error
+	GetByEmail(ctx context.Context, email string) (*User, error)
+	GetByID(ctx context.Context, id string) (*User, error)
+}
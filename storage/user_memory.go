@@ -0,0 +1,59 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	"sync"
+)
+
+// MemoryUserStore is an in-process UserStore backed by a map. It exists
+// so handlers can be exercised in tests without a running MongoDB.
+type MemoryUserStore struct {
+	mu     sync.Mutex
+	users  map[string]User
+	nextID int
+}
+
+// NewMemoryUserStore returns an empty MemoryUserStore.
+func NewMemoryUserStore() *MemoryUserStore {
+	return &MemoryUserStore{users: map[string]User{}}
+}
+
+func (s *MemoryUserStore) Create(_ context.Context, u *User) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, existing := range s.users {
+		if existing.Email == u.Email {
+			return ErrUserExists
+		}
+	}
+
+	s.nextID++
+	u.ID = fmt.Sprintf("%024x", s.nextID)
+	s.users[u.ID] = *u
+	return nil
+}
+
+func (s *MemoryUserStore) GetByEmail(_ context.Context, email string) (*User, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, u := range s.users {
+		if u.Email == email {
+			return &u, nil
+		}
+	}
+	return nil, ErrNotFound
+}
+
+func (s *MemoryUserStore) GetByID(_ context.Context, id string) (*User, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	u, ok := s.users[id]
+	if !ok {
+		return nil, ErrNotFound
+	}
+	return &u, nil
+}
@@ -0,0 +1,50 @@
+package storage
+
+import (
+	"context"
+	"testing"
+)
+
+func TestMemoryUserStoreCreateAndGet(t *testing.T) {
+	ctx := context.Background()
+	s := NewMemoryUserStore()
+
+	u := User{Email: "ada@example.com", PasswordHash: "hashed"}
+	if err := s.Create(ctx, &u); err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+	if u.ID == "" {
+		t.Fatal("Create() did not assign an ID")
+	}
+
+	byEmail, err := s.GetByEmail(ctx, "ada@example.com")
+	if err != nil {
+		t.Fatalf("GetByEmail() error = %v", err)
+	}
+	if byEmail.ID != u.ID {
+		t.Errorf("GetByEmail() ID = %q, want %q", byEmail.ID, u.ID)
+	}
+
+	byID, err := s.GetByID(ctx, u.ID)
+	if err != nil {
+		t.Fatalf("GetByID() error = %v", err)
+	}
+	if byID.Email != u.Email {
+		t.Errorf("GetByID() Email = %q, want %q", byID.Email, u.Email)
+	}
+}
+
+func TestMemoryUserStoreDuplicateEmail(t *testing.T) {
+	ctx := context.Background()
+	s := NewMemoryUserStore()
+
+	first := User{Email: "ada@example.com", PasswordHash: "hashed"}
+	if err := s.Create(ctx, &first); err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+
+	second := User{Email: "ada@example.com", PasswordHash: "other"}
+	if err := s.Create(ctx, &second); err != ErrUserExists {
+		t.Errorf("Create() error = %v, want ErrUserExists", err)
+	}
+}
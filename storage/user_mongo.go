@@ -0,0 +1,81 @@
+package storage
+
+import (
+	"context"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type mongoUser struct {
+	ID           primitive.ObjectID `bson:"_id,omitempty"`
+	Email        string             `bson:"email"`
+	PasswordHash string             `bson:"passwordHash"`
+}
+
+// MongoUserStore is a UserStore backed by the official MongoDB driver.
+type MongoUserStore struct {
+	collection *mongo.Collection
+}
+
+// NewMongoUserStore returns a MongoUserStore backed by dbName.collectionName
+// and ensures emails are unique.
+func NewMongoUserStore(ctx context.Context, client *mongo.Client, dbName, collectionName string) (*MongoUserStore, error) {
+	collection := client.Database(dbName).Collection(collectionName)
+	_, err := collection.Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys:    bson.D{{Key: "email", Value: 1}},
+		Options: options.Index().SetUnique(true),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &MongoUserStore{collection: collection}, nil
+}
+
+func (s *MongoUserStore) Create(ctx context.Context, u *User) error {
+	mu := mongoUser{
+		ID:           primitive.NewObjectID(),
+		Email:        u.Email,
+		PasswordHash: u.PasswordHash,
+	}
+	if _, err := s.collection.InsertOne(ctx, mu); err != nil {
+		if mongo.IsDuplicateKeyError(err) {
+			return ErrUserExists
+		}
+		return err
+	}
+	u.ID = mu.ID.Hex()
+	return nil
+}
+
+func (s *MongoUserStore) GetByEmail(ctx context.Context, email string) (*User, error) {
+	var mu mongoUser
+	if err := s.collection.FindOne(ctx, bson.M{"email": email}).Decode(&mu); err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, ErrNotFound
+		}
+		return nil, err
+	}
+	return fromMongoUser(mu), nil
+}
+
+func (s *MongoUserStore) GetByID(ctx context.Context, id string) (*User, error) {
+	oid, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, ErrNotFound
+	}
+	var mu mongoUser
+	if err := s.collection.FindOne(ctx, bson.M{"_id": oid}).Decode(&mu); err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, ErrNotFound
+		}
+		return nil, err
+	}
+	return fromMongoUser(mu), nil
+}
+
+func fromMongoUser(mu mongoUser) *User {
+	return &User{ID: mu.ID.Hex(), Email: mu.Email, PasswordHash: mu.PasswordHash}
+}
@@ -0,0 +1,71 @@
+package storage
+
+import (
+	"context"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// ChangeEvent is a single change observed on the todo collection.
+type ChangeEvent struct {
+	OperationType string // MongoDB operationType, e.g. "insert", "update", "replace" or "delete"
+	Todo          Todo   // for "delete", only ID and (if known) OwnerID are set
+}
+
+// Watch streams the MongoDB change stream for the todo collection,
+// calling onChange for every change event it decodes, whatever its
+// operation type (insert, update, replace, delete, ...). It is an
+// alternative to publishing from the HTTP handlers directly: because
+// the change stream is driven by MongoDB rather than in-process state,
+// every API instance behind a load balancer observes the same events,
+// which the in-process Hub alone cannot guarantee.
+//
+// Delete events carry no fullDocument (MongoDB never includes one), so
+// Watch keeps a small in-memory id->OwnerID cache populated from the
+// insert/update events it has already seen, to still route deletes to
+// the right owner. A delete for a todo this process never observed
+// being created or updated (e.g. it happened before Watch started)
+// reports an empty OwnerID and will not reach any client.
+//
+// Watch blocks until ctx is cancelled or the change stream errors.
+func (s *MongoStore) Watch(ctx context.Context, onChange func(ChangeEvent)) error {
+	opts := options.ChangeStream().SetFullDocument(options.UpdateLookup)
+	stream, err := s.collection.Watch(ctx, bson.A{}, opts)
+	if err != nil {
+		return err
+	}
+	defer stream.Close(ctx)
+
+	ownerByID := map[string]string{}
+
+	for stream.Next(ctx) {
+		var raw struct {
+			OperationType string    `bson:"operationType"`
+			FullDocument  mongoTodo `bson:"fullDocument"`
+			DocumentKey   struct {
+				ID primitive.ObjectID `bson:"_id"`
+			} `bson:"documentKey"`
+		}
+		if err := stream.Decode(&raw); err != nil {
+			continue
+		}
+		id := raw.DocumentKey.ID.Hex()
+
+		if raw.OperationType == "delete" {
+			todo := Todo{ID: id, OwnerID: ownerByID[id]}
+			delete(ownerByID, id)
+			onChange(ChangeEvent{OperationType: raw.OperationType, Todo: todo})
+			continue
+		}
+
+		todo := fromMongoTodo(raw.FullDocument)
+		if todo.ID == "" {
+			todo.ID = id
+		}
+		ownerByID[todo.ID] = todo.OwnerID
+		onChange(ChangeEvent{OperationType: raw.OperationType, Todo: todo})
+	}
+	return stream.Err()
+}
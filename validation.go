@@ -0,0 +1,42 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/go-playground/validator/v10"
+)
+
+var validate = validator.New()
+
+// decodeJSON decodes r's body into v, writing a uniform 400 response
+// and returning false on malformed JSON.
+func decodeJSON(w http.ResponseWriter, r *http.Request, v interface{}) bool {
+	if err := json.NewDecoder(r.Body).Decode(v); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid_request", "request body must be valid JSON", nil)
+		return false
+	}
+	return true
+}
+
+// validateStruct runs v's `validate` tags, writing a uniform 422
+// response listing the failing fields and returning false if any fail.
+func validateStruct(w http.ResponseWriter, v interface{}) bool {
+	if err := validate.Struct(v); err != nil {
+		writeError(w, http.StatusUnprocessableEntity, "validation_failed", "request failed validation", fieldErrors(err))
+		return false
+	}
+	return true
+}
+
+func fieldErrors(err error) []string {
+	validationErrs, ok := err.(validator.ValidationErrors)
+	if !ok {
+		return nil
+	}
+	details := make([]string, 0, len(validationErrs))
+	for _, fe := range validationErrs {
+		details = append(details, fe.Field()+" "+fe.ActualTag())
+	}
+	return details
+}